@@ -0,0 +1,200 @@
+// Package health serves the liveness and readiness endpoints.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/router"
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/version"
+)
+
+// Response is the liveness response.
+type Response struct {
+	Status    string  `json:"status"`
+	Version   string  `json:"version"`
+	CommitSHA *string `json:"commit_sha,omitempty"`
+	Timestamp string  `json:"timestamp"`
+}
+
+// Checker is a single dependency probe used by /readyz.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// checkResult is the JSON-serializable outcome of running one Checker.
+type checkResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// CheckerRegistry runs a set of Checkers in parallel, each bounded by
+// Timeout, and reports every dependency's status and latency.
+type CheckerRegistry struct {
+	Checkers []Checker
+	Timeout  time.Duration
+}
+
+// run executes every registered Checker concurrently and returns whether
+// all of them succeeded, along with a per-checker result.
+func (reg CheckerRegistry) run(ctx context.Context) (bool, []checkResult) {
+	results := make([]checkResult, len(reg.Checkers))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		healthy = true
+	)
+
+	for i, checker := range reg.Checkers {
+		wg.Add(1)
+		go func(i int, checker Checker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, reg.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := checker.Check(checkCtx)
+			result := checkResult{
+				Name:    checker.Name(),
+				Status:  "ok",
+				Latency: time.Since(start).String(),
+			}
+			if err != nil {
+				result.Status = "error"
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[i] = result
+			if err != nil {
+				healthy = false
+			}
+			mu.Unlock()
+		}(i, checker)
+	}
+
+	wg.Wait()
+	return healthy, results
+}
+
+// TCPChecker is healthy when it can dial Addr over TCP.
+type TCPChecker struct {
+	CheckerName string
+	Addr        string
+}
+
+func (c TCPChecker) Name() string { return c.CheckerName }
+
+func (c TCPChecker) Check(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", c.Addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// HTTPChecker is healthy when a GET to URL returns a 2xx status.
+type HTTPChecker struct {
+	CheckerName string
+	URL         string
+}
+
+func (c HTTPChecker) Name() string { return c.CheckerName }
+
+func (c HTTPChecker) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SQLChecker is healthy when DB responds to a ping.
+type SQLChecker struct {
+	CheckerName string
+	DB          *sql.DB
+}
+
+func (c SQLChecker) Name() string { return c.CheckerName }
+
+func (c SQLChecker) Check(ctx context.Context) error {
+	return c.DB.PingContext(ctx)
+}
+
+// LivezHandler reports only that the process is up, with no dependency
+// checks, for use as a liveness probe.
+type LivezHandler struct{}
+
+// RegisterRoutes mounts /livez under /api/v1, plus a deprecated
+// unversioned alias at /health.
+func (h LivezHandler) RegisterRoutes(r *gin.Engine) {
+	v1 := r.Group("/api/v1")
+	v1.GET("/livez", h.handle)
+
+	router.DeprecatedAlias(r, http.MethodGet, "/health", h.handle)
+}
+
+func (h LivezHandler) handle(c *gin.Context) {
+	commitSHA := os.Getenv("COMMIT_SHA")
+	response := Response{
+		Status:    "healthy",
+		Version:   version.Version,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	if commitSHA != "" {
+		response.CommitSHA = &commitSHA
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ReadyzHandler reports whether every registered dependency Checker is
+// healthy, for use as a readiness probe.
+type ReadyzHandler struct {
+	Registry CheckerRegistry
+}
+
+// RegisterRoutes mounts /readyz under /api/v1.
+func (h ReadyzHandler) RegisterRoutes(r *gin.Engine) {
+	v1 := r.Group("/api/v1")
+	v1.GET("/readyz", h.handle)
+}
+
+func (h ReadyzHandler) handle(c *gin.Context) {
+	healthy, results := h.Registry.run(c.Request.Context())
+
+	status := "ok"
+	httpStatus := http.StatusOK
+	if !healthy {
+		status = "unavailable"
+		httpStatus = http.StatusServiceUnavailable
+	}
+
+	c.JSON(httpStatus, gin.H{
+		"status": status,
+		"checks": results,
+	})
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeChecker struct {
+	name  string
+	err   error
+	delay time.Duration
+}
+
+func (c fakeChecker) Name() string { return c.name }
+
+func (c fakeChecker) Check(ctx context.Context) error {
+	select {
+	case <-time.After(c.delay):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestCheckerRegistryRunAllHealthy(t *testing.T) {
+	reg := CheckerRegistry{
+		Checkers: []Checker{
+			fakeChecker{name: "a"},
+			fakeChecker{name: "b"},
+		},
+		Timeout: time.Second,
+	}
+
+	healthy, results := reg.run(context.Background())
+
+	if !healthy {
+		t.Fatalf("healthy = false, want true; results = %+v", results)
+	}
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	for _, r := range results {
+		if r.Status != "ok" {
+			t.Errorf("checker %q status = %q, want ok", r.Name, r.Status)
+		}
+	}
+}
+
+func TestCheckerRegistryRunReportsFailure(t *testing.T) {
+	reg := CheckerRegistry{
+		Checkers: []Checker{
+			fakeChecker{name: "ok"},
+			fakeChecker{name: "down", err: errors.New("connection refused")},
+		},
+		Timeout: time.Second,
+	}
+
+	healthy, results := reg.run(context.Background())
+
+	if healthy {
+		t.Fatal("healthy = true, want false")
+	}
+
+	var sawFailure bool
+	for _, r := range results {
+		if r.Name == "down" {
+			sawFailure = true
+			if r.Status != "error" || r.Error == "" {
+				t.Errorf("down checker result = %+v, want status=error with a message", r)
+			}
+		}
+	}
+	if !sawFailure {
+		t.Fatal("results did not include the failing checker")
+	}
+}
+
+func TestCheckerRegistryRunRespectsTimeout(t *testing.T) {
+	reg := CheckerRegistry{
+		Checkers: []Checker{
+			fakeChecker{name: "slow", delay: 50 * time.Millisecond},
+		},
+		Timeout: time.Millisecond,
+	}
+
+	start := time.Now()
+	healthy, results := reg.run(context.Background())
+	elapsed := time.Since(start)
+
+	if healthy {
+		t.Fatal("healthy = true, want false for a checker exceeding its timeout")
+	}
+	if elapsed >= 50*time.Millisecond {
+		t.Errorf("run took %s, want it to return once the per-check timeout elapsed rather than waiting for the checker", elapsed)
+	}
+	if len(results) != 1 || results[0].Error == "" {
+		t.Errorf("results = %+v, want a single timed-out result with an error", results)
+	}
+}
+
+func TestCheckerRegistryRunIsConcurrent(t *testing.T) {
+	const n = 5
+	checkers := make([]Checker, n)
+	for i := range checkers {
+		checkers[i] = fakeChecker{name: string(rune('a' + i)), delay: 20 * time.Millisecond}
+	}
+	reg := CheckerRegistry{Checkers: checkers, Timeout: time.Second}
+
+	start := time.Now()
+	healthy, _ := reg.run(context.Background())
+	elapsed := time.Since(start)
+
+	if !healthy {
+		t.Fatal("healthy = false, want true")
+	}
+	if elapsed >= n*20*time.Millisecond {
+		t.Errorf("run took %s, want checkers to run concurrently rather than sequentially (n=%d * 20ms)", elapsed, n)
+	}
+}
@@ -0,0 +1,36 @@
+// Package root serves the landing-page endpoint.
+package root
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/router"
+)
+
+// Response is a simple message response.
+type Response struct {
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Handler serves the friendly landing-page message.
+type Handler struct{}
+
+// RegisterRoutes mounts / under /api/v1, plus a deprecated unversioned
+// alias at the engine root.
+func (h Handler) RegisterRoutes(r *gin.Engine) {
+	v1 := r.Group("/api/v1")
+	v1.GET("/", h.handle)
+
+	router.DeprecatedAlias(r, http.MethodGet, "/", h.handle)
+}
+
+func (h Handler) handle(c *gin.Context) {
+	c.JSON(http.StatusOK, Response{
+		Message:   "Hello from Go/Gin!",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,54 @@
+// Package status serves the API status/environment endpoint.
+package status
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/router"
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/version"
+)
+
+// Response is the API status response.
+type Response struct {
+	API         string `json:"api"`
+	Version     string `json:"version"`
+	Environment string `json:"environment"`
+	Port        string `json:"port"`
+}
+
+// Handler serves the API status/environment endpoint.
+type Handler struct{}
+
+// RegisterRoutes mounts /status under /api/v1, plus a deprecated
+// unversioned alias at /api/status.
+func (h Handler) RegisterRoutes(r *gin.Engine) {
+	v1 := r.Group("/api/v1")
+	v1.GET("/status", h.handle)
+
+	router.DeprecatedAlias(r, http.MethodGet, "/api/status", h.handle)
+}
+
+func (h Handler) handle(c *gin.Context) {
+	port := os.Getenv("APP_PORT")
+	if port == "" {
+		port = os.Getenv("PORT")
+	}
+	if port == "" {
+		port = "3000"
+	}
+
+	environment := os.Getenv("ENVIRONMENT")
+	if environment == "" {
+		environment = "development"
+	}
+
+	c.JSON(http.StatusOK, Response{
+		API:         "running",
+		Version:     version.Version,
+		Environment: environment,
+		Port:        port,
+	})
+}
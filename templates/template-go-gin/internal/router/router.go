@@ -0,0 +1,44 @@
+// Package router wires a set of route-owning handlers onto a gin.Engine,
+// so adding a new feature means adding a new Handler rather than touching
+// main.go.
+package router
+
+import "github.com/gin-gonic/gin"
+
+// Handler registers its own routes directly on the engine. Each handler
+// owns its own path prefix (typically the versioned /api/v1 group), so
+// the Router itself stays ignorant of what any one handler serves.
+type Handler interface {
+	RegisterRoutes(r *gin.Engine)
+}
+
+// Router mounts a fixed set of Handlers onto a gin.Engine.
+type Router struct {
+	engine   *gin.Engine
+	handlers []Handler
+}
+
+// New builds a Router that will mount handlers onto engine.
+func New(engine *gin.Engine, handlers ...Handler) *Router {
+	return &Router{engine: engine, handlers: handlers}
+}
+
+// RegisterRoutes mounts every handler onto the underlying engine.
+func (rt *Router) RegisterRoutes() {
+	for _, h := range rt.handlers {
+		h.RegisterRoutes(rt.engine)
+	}
+}
+
+// DeprecatedAlias re-exposes path at the engine root using the given
+// handler chain, tagging the response with a Deprecation header so
+// existing unversioned callers keep working while new integrations move
+// to /api/v1.
+func DeprecatedAlias(r *gin.Engine, method, path string, handlers ...gin.HandlerFunc) {
+	chain := append([]gin.HandlerFunc{deprecationHeader}, handlers...)
+	r.Handle(method, path, chain...)
+}
+
+func deprecationHeader(c *gin.Context) {
+	c.Header("Deprecation", "true")
+}
@@ -0,0 +1,6 @@
+// Package version holds the build version surfaced on the health/status
+// endpoints and the build_info metric.
+package version
+
+// Version is the current build version.
+const Version = "1.0.0"
@@ -1,109 +1,150 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/handlers/health"
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/handlers/root"
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/handlers/status"
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/router"
 )
 
-// HealthResponse represents the health check response
-type HealthResponse struct {
-	Status    string  `json:"status"`
-	Version   string  `json:"version"`
-	CommitSHA *string `json:"commit_sha,omitempty"`
-	Timestamp string  `json:"timestamp"`
-}
+// newRouter builds the Gin engine and registers all routes without binding
+// a port, so it can be exercised directly in tests. reg is used to record
+// RED metrics and, when METRICS_PORT is unset, to serve /metrics directly.
+// logger receives structured request logs tagged with the request ID.
+func newRouter(reg *prometheus.Registry, logger *zap.Logger) *gin.Engine {
+	// Create Gin router with structured logging instead of gin.Default()'s
+	// text logger, so operators get correlatable JSON logs.
+	r := gin.New()
+	r.Use(requestIDMiddleware())
+	r.Use(newGinLoggerMiddlewares(logger)...)
+
+	// Add middleware for CORS
+	r.Use(cors.New(corsConfig()))
+
+	if envBool("METRICS_ENABLED", true) {
+		m := newMetrics(reg)
+		r.Use(m.middleware())
+
+		if os.Getenv("METRICS_PORT") == "" {
+			r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})))
+		}
+	}
 
-// MessageResponse represents a simple message response
-type MessageResponse struct {
-	Message   string `json:"message"`
-	Timestamp string `json:"timestamp"`
-}
+	rt := router.New(r,
+		root.Handler{},
+		health.LivezHandler{},
+		health.ReadyzHandler{Registry: health.CheckerRegistry{Timeout: envDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second)}},
+		status.Handler{},
+	)
+	rt.RegisterRoutes()
 
-// StatusResponse represents the API status response
-type StatusResponse struct {
-	API         string `json:"api"`
-	Version     string `json:"version"`
-	Environment string `json:"environment"`
-	Port        string `json:"port"`
+	return r
 }
 
-func main() {
-	// Load environment variables from .env file if it exists
-	_ = godotenv.Load()
-
-	// Set Gin mode based on environment
-	if os.Getenv("ENVIRONMENT") == "production" {
-		gin.SetMode(gin.ReleaseMode)
+// envList splits a comma-separated env var into a trimmed slice, falling
+// back to def when unset.
+func envList(key string, def []string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
 	}
-
-	// Create Gin router
-	r := gin.Default()
-
-	// Add middleware for CORS and logging
-	r.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+	parts := strings.Split(val, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
 		}
+	}
+	return out
+}
 
-		c.Next()
-	})
+// envBool reads an env var as a bool, falling back to def when unset or
+// invalid.
+func envBool(key string, def bool) bool {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return def
+	}
+	return b
+}
 
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		commitSHA := os.Getenv("COMMIT_SHA")
-		response := HealthResponse{
-			Status:    "healthy",
-			Version:   "1.0.0",
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-		}
-		if commitSHA != "" {
-			response.CommitSHA = &commitSHA
-		}
-		c.JSON(http.StatusOK, response)
-	})
-
-	// Root endpoint
-	r.GET("/", func(c *gin.Context) {
-		c.JSON(http.StatusOK, MessageResponse{
-			Message:   "Hello from Go/Gin!",
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-		})
-	})
-
-	// API status endpoint
-	r.GET("/api/status", func(c *gin.Context) {
-		port := os.Getenv("APP_PORT")
-		if port == "" {
-			port = os.Getenv("PORT")
+// corsConfig builds the CORS policy from env vars. The current
+// Access-Control-Allow-Origin: * combined with Authorization in allowed
+// headers is unsafe for any authenticated deployment, so origins must be
+// allowlisted explicitly when credentials are enabled, and default to
+// same-origin only in production.
+func corsConfig() cors.Config {
+	allowCredentials := envBool("CORS_ALLOW_CREDENTIALS", false)
+	origins := envList("CORS_ALLOWED_ORIGINS", nil)
+
+	cfg := cors.Config{
+		AllowMethods:     envList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
+		AllowHeaders:     envList("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization"}),
+		AllowCredentials: allowCredentials,
+		MaxAge:           envDuration("CORS_MAX_AGE", 12*time.Hour),
+	}
+
+	switch {
+	case allowCredentials:
+		allowed := make(map[string]bool, len(origins))
+		for _, o := range origins {
+			allowed[o] = true
 		}
-		if port == "" {
-			port = "3000"
+		cfg.AllowOriginFunc = func(origin string) bool {
+			return allowed[origin]
 		}
+	case len(origins) > 0:
+		cfg.AllowOrigins = origins
+	case os.Getenv("ENVIRONMENT") == "production":
+		cfg.AllowOriginFunc = func(origin string) bool { return false }
+	default:
+		cfg.AllowOrigins = []string{"*"}
+	}
 
-		environment := os.Getenv("ENVIRONMENT")
-		if environment == "" {
-			environment = "development"
-		}
+	return cfg
+}
 
-		c.JSON(http.StatusOK, StatusResponse{
-			API:         "running",
-			Version:     "1.0.0",
-			Environment: environment,
-			Port:        port,
-		})
-	})
+// envDuration reads an env var as a duration in seconds, falling back to
+// def when unset or invalid.
+func envDuration(key string, def time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
+	}
+	secs, err := time.ParseDuration(val + "s")
+	if err != nil {
+		return def
+	}
+	return secs
+}
 
-	// Start server
+// runServer starts an http.Server wrapping r and blocks until ctx is
+// cancelled, at which point it attempts a graceful shutdown bounded by
+// SHUTDOWN_TIMEOUT (default 5s) so in-flight connections aren't dropped
+// when running behind a reverse proxy.
+func runServer(ctx context.Context, r *gin.Engine) error {
 	port := os.Getenv("APP_PORT")
 	if port == "" {
 		port = os.Getenv("PORT")
@@ -112,9 +153,70 @@ func main() {
 		port = "3000"
 	}
 
-	log.Printf("Starting server on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	srv := &http.Server{
+		Addr:         ":" + port,
+		Handler:      r,
+		ReadTimeout:  envDuration("READ_TIMEOUT", 10*time.Second),
+		WriteTimeout: envDuration("WRITE_TIMEOUT", 10*time.Second),
+		IdleTimeout:  envDuration("IDLE_TIMEOUT", 120*time.Second),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Starting server on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownTimeout := envDuration("SHUTDOWN_TIMEOUT", 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	log.Println("Shutting down server...")
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
 	}
+	log.Println("Server exited gracefully")
+	return nil
 }
 
+func main() {
+	// Load environment variables from .env file if it exists
+	_ = godotenv.Load()
+
+	// Set Gin mode based on environment
+	if os.Getenv("ENVIRONMENT") == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger, err := newLogger()
+	if err != nil {
+		log.Fatal("Failed to initialize logger:", err)
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	reg := prometheus.NewRegistry()
+	r := newRouter(reg, logger)
+
+	errCh := make(chan error, 2)
+	go func() { errCh <- runServer(ctx, r) }()
+	go func() { errCh <- runMetricsServer(ctx, reg) }()
+
+	for i := 0; i < cap(errCh); i++ {
+		if err := <-errCh; err != nil {
+			log.Fatal("Failed to start server:", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+func testRouter(t *testing.T) http.Handler {
+	t.Helper()
+	return newRouter(prometheus.NewRegistry(), zap.NewNop())
+}
+
+func TestNewRouterServesRoutesWithoutBindingPort(t *testing.T) {
+	r := testRouter(t)
+
+	for _, path := range []string{"/api/v1/", "/api/v1/livez", "/api/v1/status"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: got status %d, want %d", path, rec.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestNewRouterDeprecatedAliasesSetHeader(t *testing.T) {
+	r := testRouter(t)
+
+	for _, path := range []string{"/", "/health", "/api/status"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s: got status %d, want %d", path, rec.Code, http.StatusOK)
+		}
+		if got := rec.Header().Get("Deprecation"); got != "true" {
+			t.Errorf("GET %s: Deprecation header = %q, want %q", path, got, "true")
+		}
+	}
+}
+
+func TestNewRouterExposesMetrics(t *testing.T) {
+	r := testRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics: got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "http_requests_total") {
+		t.Error("GET /metrics: response did not include http_requests_total")
+	}
+}
+
+func TestCorsConfigDefaultAllowsAnyOrigin(t *testing.T) {
+	cfg := corsConfig()
+
+	if len(cfg.AllowOrigins) != 1 || cfg.AllowOrigins[0] != "*" {
+		t.Errorf("AllowOrigins = %v, want [*]", cfg.AllowOrigins)
+	}
+}
+
+func TestCorsConfigProductionDefaultDeniesOrigins(t *testing.T) {
+	t.Setenv("ENVIRONMENT", "production")
+
+	cfg := corsConfig()
+
+	if cfg.AllowOriginFunc == nil {
+		t.Fatal("AllowOriginFunc is nil, want a same-origin-only func")
+	}
+	if cfg.AllowOriginFunc("https://example.com") {
+		t.Error("AllowOriginFunc allowed an origin in production with no allowlist")
+	}
+}
+
+func TestCorsConfigCredentialedUsesAllowlist(t *testing.T) {
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://allowed.example.com")
+
+	cfg := corsConfig()
+
+	if cfg.AllowOriginFunc == nil {
+		t.Fatal("AllowOriginFunc is nil, want an allowlist func")
+	}
+	if !cfg.AllowOriginFunc("https://allowed.example.com") {
+		t.Error("AllowOriginFunc rejected an allowlisted origin")
+	}
+	if cfg.AllowOriginFunc("https://evil.example.com") {
+		t.Error("AllowOriginFunc allowed an origin outside the allowlist")
+	}
+}
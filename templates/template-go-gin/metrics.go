@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Yarden-zamir/vps-manager/templates/template-go-gin/internal/version"
+)
+
+// metrics holds the RED-style collectors recorded on every request.
+type metrics struct {
+	requestsTotal    *prometheus.CounterVec
+	requestsInFlight *prometheus.GaugeVec
+	requestDuration  *prometheus.HistogramVec
+}
+
+// newMetrics registers the HTTP and build-info collectors, plus the Go
+// runtime collectors, on reg.
+func newMetrics(reg *prometheus.Registry) *metrics {
+	factory := promauto.With(reg)
+
+	m := &metrics{
+		requestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests processed.",
+		}, []string{"method", "route", "status"}),
+		requestsInFlight: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		}, []string{"method", "route"}),
+		requestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+	}
+
+	reg.MustRegister(collectors.NewGoCollector())
+	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	buildInfo := factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "build_info",
+		Help: "Build information, value is always 1.",
+	}, []string{"version", "commit_sha"})
+	buildInfo.WithLabelValues(version.Version, os.Getenv("COMMIT_SHA")).Set(1)
+
+	return m
+}
+
+// middleware records request count, in-flight requests, and latency
+// labeled by method, route template, and status.
+func (m *metrics) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		method := c.Request.Method
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.requestsInFlight.WithLabelValues(method, route).Inc()
+		defer m.requestsInFlight.WithLabelValues(method, route).Dec()
+
+		c.Next()
+
+		status := strconv.Itoa(c.Writer.Status())
+		m.requestsTotal.WithLabelValues(method, route, status).Inc()
+		m.requestDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// runMetricsServer optionally exposes /metrics on a separate admin port so
+// it isn't reachable from the public listener, shutting down gracefully
+// alongside the main server when ctx is cancelled.
+func runMetricsServer(ctx context.Context, reg *prometheus.Registry) error {
+	if !envBool("METRICS_ENABLED", true) {
+		return nil
+	}
+
+	adminPort := os.Getenv("METRICS_PORT")
+	if adminPort == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+
+	srv := &http.Server{
+		Addr:    ":" + adminPort,
+		Handler: mux,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Starting metrics server on port %s", adminPort)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), envDuration("SHUTDOWN_TIMEOUT", 5*time.Second))
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}